@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AsyncSource is a named backend fetch that a page can declare so the engine
+// runs it concurrently with its siblings and merges the result into the
+// template context before Render is called (see FetchSources).
+type AsyncSource interface {
+	Fetch(ctx context.Context) (interface{}, error)
+}
+
+// FetchSources runs every source concurrently over a shared context and
+// returns their results keyed by name, ready to be exposed to templates
+// under a top-level "Sources.<name>" key alongside Data/Extra/Params.
+//
+// If a source fails, every other source still in flight is cancelled and the
+// first error is returned. timeout bounds each individual source; zero means
+// no per-source timeout.
+func FetchSources(ctx context.Context, sources map[string]AsyncSource, timeout time.Duration) (map[string]interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		name string
+		data interface{}
+		err  error
+	}
+
+	results := make(chan result, len(sources))
+	for name, source := range sources {
+		name, source := name, source
+		go func() {
+			sctx := ctx
+			if timeout > 0 {
+				var scancel context.CancelFunc
+				sctx, scancel = context.WithTimeout(ctx, timeout)
+				defer scancel()
+			}
+			data, err := source.Fetch(sctx)
+			select {
+			case results <- result{name, data, err}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	merged := make(map[string]interface{}, len(sources))
+	for range sources {
+		select {
+		case r := <-results:
+			if r.err != nil {
+				cancel()
+				return nil, fmt.Errorf("engine: fetching source %q: %w", r.name, r.err)
+			}
+			merged[r.name] = r.data
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fetchFunc func(ctx context.Context) (interface{}, error)
+
+func (f fetchFunc) Fetch(ctx context.Context) (interface{}, error) { return f(ctx) }
+
+func TestFetchSourcesMergesResults(t *testing.T) {
+	sources := map[string]AsyncSource{
+		"a": fetchFunc(func(ctx context.Context) (interface{}, error) { return "A", nil }),
+		"b": fetchFunc(func(ctx context.Context) (interface{}, error) { return "B", nil }),
+	}
+
+	merged, err := FetchSources(context.Background(), sources, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged["a"] != "A" || merged["b"] != "B" {
+		t.Fatalf("merged = %v, want a=A b=B", merged)
+	}
+}
+
+func TestFetchSourcesCancelsSiblingsOnError(t *testing.T) {
+	cancelled := make(chan struct{})
+	sources := map[string]AsyncSource{
+		"fails": fetchFunc(func(ctx context.Context) (interface{}, error) {
+			return nil, errors.New("boom")
+		}),
+		"slow": fetchFunc(func(ctx context.Context) (interface{}, error) {
+			select {
+			case <-ctx.Done():
+				close(cancelled)
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return "too slow", nil
+			}
+		}),
+	}
+
+	_, err := FetchSources(context.Background(), sources, 0)
+	if err == nil || !strings.Contains(err.Error(), "fails") {
+		t.Fatalf("FetchSources() error = %v, want it to name the failing source", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("sibling source was not cancelled after another source failed")
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import "path/filepath"
+
+// EngineName identifies one of the template engines registered in this package.
+type EngineName string
+
+// Built-in engines. There is no EngineHandlebars yet: a .hbs/.handlebars file
+// has real Handlebars semantics (block helpers, subexpressions, partial
+// arguments) that plain mustache rules silently mis-render instead of
+// rejecting, so until a real implementation exists such files must be
+// assigned an engine explicitly via Config.Engines.
+const (
+	EngineMustache EngineName = "mustache"
+	EngineHTML     EngineName = "html"
+	EngineText     EngineName = "text"
+)
+
+// Config describes the templates and layouts the renderer layer must build.
+type Config struct {
+	// Templates maps a template name to its file path.
+	Templates map[string]string
+	// Layouts maps a layout name to its file path.
+	Layouts map[string]string
+	// Engines optionally overrides the engine used to parse a given template
+	// or layout name. When a name is absent here the engine is inferred from
+	// its file extension (see engineFor).
+	Engines map[string]EngineName
+	// StrictMode makes MustacheRenderer.Render and LayoutMustacheRenderer.Render
+	// return a *MissingKeysError instead of silently rendering empty values
+	// when a variable, section or partial cannot be resolved.
+	StrictMode bool
+	// Helpers registers additional mustache lambdas (see FuncMap) on top of
+	// DefaultHelpers, reachable from templates under "Helpers.<name>".
+	Helpers FuncMap
+}
+
+// engineFor resolves the EngineName to use for name, preferring an explicit
+// entry in cfg.Engines and falling back to the file extension of path.
+func engineFor(name, path string, cfg Config) EngineName {
+	if e, ok := cfg.Engines[name]; ok && e != "" {
+		return e
+	}
+	switch filepath.Ext(path) {
+	case ".html", ".htm":
+		return EngineHTML
+	case ".tmpl", ".txt":
+		return EngineText
+	default:
+		return EngineMustache
+	}
+}
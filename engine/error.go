@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrorMode selects how much detail RenderError exposes.
+type ErrorMode string
+
+const (
+	// ErrorModeDevelopment surfaces the failing template name, line/column,
+	// the resolved backend response and the request context.
+	ErrorModeDevelopment ErrorMode = "development"
+	// ErrorModeProduction shows only a generic message plus a correlation ID.
+	ErrorModeProduction ErrorMode = "production"
+)
+
+// templateErrRegexp parses the location out of the errors text/template (and
+// therefore html/template, which wraps it) produce, e.g.
+// `template: page:12:5: executing "page" at <.Foo>: ...`. It does not match
+// github.com/cbroglie/mustache errors, which carry no line/column
+// information (e.g. `missing variable %q`, `lambda %q: %w`) -- for templates
+// rendered by the mustache engine, Template/Line/Column/At are simply left
+// unset and Error still carries the original message.
+var templateErrRegexp = regexp.MustCompile(`^template: (.*):(\d+):(\d+): executing "(.*)" at <(.*)>:`)
+
+// ErrorConfig configures RenderError.
+type ErrorConfig struct {
+	// Mode selects how much detail is shown to the client.
+	Mode ErrorMode
+	// CorrelationID extracts (or generates) the ID shown to the client in
+	// ErrorModeProduction and logged alongside the original error.
+	CorrelationID func(r *http.Request) string
+	// Context extracts the backend response and/or request context to show
+	// in ErrorModeDevelopment. May be nil.
+	Context func(r *http.Request) interface{}
+	// Template overrides the built-in "api2html/error" partial.
+	Template Renderer
+}
+
+// RenderError writes a styled 500 page for err through cfg.Template (or the
+// built-in fallback). In ErrorModeDevelopment it includes the failing
+// template name, line/column parsed out of err, the result of cfg.Context and
+// err itself; in ErrorModeProduction it only shows a generic message plus the
+// correlation ID, which is also logged.
+func RenderError(w http.ResponseWriter, r *http.Request, cfg ErrorConfig, err error) {
+	correlationID := ""
+	if cfg.CorrelationID != nil {
+		correlationID = cfg.CorrelationID(r)
+	}
+	log.Printf("rendering error page [%s]: %s", correlationID, err.Error())
+
+	view := map[string]interface{}{
+		"Mode":          cfg.Mode,
+		"CorrelationID": correlationID,
+	}
+	if cfg.Mode == ErrorModeDevelopment {
+		view["Error"] = err.Error()
+		if m := templateErrRegexp.FindStringSubmatch(err.Error()); m != nil {
+			view["Template"] = m[1]
+			view["Line"] = m[2]
+			view["Column"] = m[3]
+			view["At"] = m[5]
+		}
+		if cfg.Context != nil {
+			view["Context"] = cfg.Context(r)
+		}
+	}
+
+	renderer := cfg.Template
+	if renderer == nil {
+		renderer = errorRenderer
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	if rerr := renderer.Render(w, view); rerr != nil {
+		log.Println("rendering error page:", rerr.Error())
+		fmt.Fprintf(w, "Internal Server Error [%s]", correlationID)
+	}
+}
+
+// errorRenderer renders the built-in "api2html/error" partial, used whenever
+// ErrorConfig.Template is nil.
+var errorRenderer Renderer
+
+func init() {
+	r, err := NewMustacheRenderer(strings.NewReader(errorTmpl))
+	if err != nil {
+		panic("engine: invalid built-in error template: " + err.Error())
+	}
+	errorRenderer = r
+	partials["api2html/error"] = errorTmpl
+}
+
+var errorTmpl = `
+<div>
+	<h1>Oops, something went wrong</h1>
+	{{ #CorrelationID }}<small>reference: {{ CorrelationID }}</small>{{ /CorrelationID }}
+	{{ #Template }}
+	<h3>Template error</h3>
+	<div><pre>{{ Template }}:{{ Line }}:{{ Column }} at {{ At }}</pre></div>
+	{{ /Template }}
+	{{ #Error }}
+	<h3>Details</h3>
+	<div><pre>{{ Error }}</pre></div>
+	{{ /Error }}
+	{{ #Context }}
+	<h3>Request context</h3>
+	<div><pre>{{ . }}</pre></div>
+	{{ /Context }}
+</div>`
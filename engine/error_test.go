@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestRenderErrorDevelopmentExtractsTemplateLocation(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse("{{.Foo.Bar}}"))
+	execErr := tmpl.Execute(ioutil.Discard, struct{}{})
+	if execErr == nil {
+		t.Fatal("expected a template execution error")
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	cfg := ErrorConfig{
+		Mode:          ErrorModeDevelopment,
+		CorrelationID: func(*http.Request) string { return "req-123" },
+	}
+
+	RenderError(rec, req, cfg, execErr)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"page", "req-123"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("body %q does not contain %q", body, want)
+		}
+	}
+}
+
+func TestRenderErrorProductionHidesDetails(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	cfg := ErrorConfig{
+		Mode:          ErrorModeProduction,
+		CorrelationID: func(*http.Request) string { return "req-456" },
+	}
+
+	RenderError(rec, req, cfg, errors.New("super secret backend response"))
+
+	body := rec.Body.String()
+	if strings.Contains(body, "secret") {
+		t.Fatalf("production body leaked error details: %q", body)
+	}
+	if !strings.Contains(body, "req-456") {
+		t.Fatalf("production body missing correlation ID: %q", body)
+	}
+}
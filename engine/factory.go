@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+)
+
+// RendererFactory builds Renderers for a given template engine. Every engine
+// supported by this package (mustache, html/template, text/template...)
+// registers one so the rest of the package can stay engine-agnostic.
+type RendererFactory interface {
+	// New parses a single template read from t.
+	New(t io.Reader) (Renderer, error)
+	// NewWithLayout parses a template read from t composed with the layout read from l.
+	NewWithLayout(t, l io.Reader) (Renderer, error)
+}
+
+// RendererFactoryBuilder builds a RendererFactory configured from cfg (e.g.
+// mustache reads cfg.StrictMode). Engines that don't need any configuration
+// can ignore cfg and return a shared value.
+type RendererFactoryBuilder func(cfg Config) RendererFactory
+
+var registry = map[EngineName]RendererFactoryBuilder{}
+
+// RegisterRendererFactory makes a RendererFactoryBuilder available under name.
+// Engine implementations are expected to call this from an init function,
+// mirroring the way database/sql drivers register themselves.
+func RegisterRendererFactory(name EngineName, build RendererFactoryBuilder) {
+	registry[name] = build
+}
+
+func factoryFor(name EngineName, cfg Config) (RendererFactory, error) {
+	build, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("engine: no RendererFactory registered for %q", name)
+	}
+	return build(cfg), nil
+}
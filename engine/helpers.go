@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cbroglie/mustache"
+)
+
+// FuncMap is a set of named helper functions exposed to mustache templates as
+// lambdas (https://mustache.github.io/mustache.5.html#Lambdas), reachable
+// under the top-level "Helpers" key, e.g. {{#Helpers.uppercase}}{{name}}{{/Helpers.uppercase}}.
+// A value is either a mustache.LambdaFunc for full control over the rendered
+// section body, or the simpler func(string) string: mustache renders the
+// section body first and passes the result in, and the helper's return value
+// replaces it. The latter is automatically adapted into a LambdaFunc.
+type FuncMap map[string]interface{}
+
+// DefaultHelpers is the built-in helper library: string (uppercase,
+// lowercase, trim), url (urlEncode) and json helpers. It holds no date or
+// number helper; FormatDate and FormatNumber build those and are not part of
+// it. It is merged into every mustache renderer's context; a Config.Helpers
+// entry with the same name takes precedence.
+var DefaultHelpers = FuncMap{
+	"uppercase": strings.ToUpper,
+	"lowercase": strings.ToLower,
+	"trim":      strings.TrimSpace,
+	"urlEncode": url.QueryEscape,
+	"json":      helperJSON,
+}
+
+func helperJSON(v string) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	return string(b)
+}
+
+// FormatDate returns a helper that parses its input as RFC3339 and
+// re-formats it with layout, e.g. Config.Helpers["shortDate"] = FormatDate("2006-01-02").
+func FormatDate(layout string) func(string) string {
+	return func(v string) string {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return v
+		}
+		return t.Format(layout)
+	}
+}
+
+// FormatNumber returns a helper that parses its input as a float64 and
+// re-formats it with the given number of decimals, e.g. for currency amounts.
+func FormatNumber(decimals int) func(string) string {
+	return func(v string) string {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return v
+		}
+		return strconv.FormatFloat(f, 'f', decimals, 64)
+	}
+}
+
+// mergeHelpers returns DefaultHelpers overridden by user, with every entry
+// adapted into a mustache.LambdaFunc, without mutating either argument.
+func mergeHelpers(user FuncMap) FuncMap {
+	merged := make(FuncMap, len(DefaultHelpers)+len(user))
+	for k, v := range DefaultHelpers {
+		merged[k] = asLambda(v)
+	}
+	for k, v := range user {
+		merged[k] = asLambda(v)
+	}
+	return merged
+}
+
+// asLambda adapts fn into a mustache.LambdaFunc if it is the simpler
+// func(string) string shape; anything else (including an already-valid
+// mustache.LambdaFunc) is returned unchanged.
+func asLambda(fn interface{}) interface{} {
+	simple, ok := fn.(func(string) string)
+	if !ok {
+		return fn
+	}
+	return mustache.LambdaFunc(func(text string, render mustache.RenderFunc) (string, error) {
+		rendered, err := render(text)
+		if err != nil {
+			return "", err
+		}
+		return simple(rendered), nil
+	})
+}
@@ -0,0 +1,22 @@
+package engine
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDefaultHelperUppercaseLambda(t *testing.T) {
+	r, err := NewMustacheRenderer(strings.NewReader("{{#Helpers.uppercase}}{{Name}}{{/Helpers.uppercase}}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, map[string]interface{}{"Name": "ada"}); err != nil {
+		t.Fatalf("Render() = %v, want nil", err)
+	}
+	if got := buf.String(); got != "ADA" {
+		t.Fatalf("Render() output = %q, want %q", got, "ADA")
+	}
+}
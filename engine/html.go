@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"io/ioutil"
+)
+
+func init() {
+	RegisterRendererFactory(EngineHTML, func(Config) RendererFactory {
+		return htmlRendererFactory{}
+	})
+}
+
+// htmlRendererFactory is the RendererFactory for EngineHTML, backed by the
+// standard library's html/template. Unlike mustache it contextually escapes
+// its output, so it's the engine to reach for when a template's output needs
+// that guarantee. The built-in api2html/debug partial is still mustache
+// (mustache partials can only be resolved by the mustache parser that
+// includes them), so it does not get this escaping today.
+type htmlRendererFactory struct{}
+
+func (htmlRendererFactory) New(t io.Reader) (Renderer, error) {
+	return NewHTMLRenderer(t)
+}
+
+func (htmlRendererFactory) NewWithLayout(t, l io.Reader) (Renderer, error) {
+	return NewHTMLLayoutRenderer(t, l)
+}
+
+// HTMLRenderer is a simple html/template renderer with a single template
+type HTMLRenderer struct {
+	tmpl *template.Template
+}
+
+// NewHTMLRenderer returns an HTMLRenderer and an error if something went wrong
+func NewHTMLRenderer(r io.Reader) (*HTMLRenderer, error) {
+	tmpl, err := newHTMLTemplate(r)
+	if err != nil {
+		return nil, err
+	}
+	return &HTMLRenderer{tmpl}, nil
+}
+
+// Render implements the Renderer interface
+func (h HTMLRenderer) Render(w io.Writer, v interface{}) error {
+	return h.tmpl.Execute(w, v)
+}
+
+// NewHTMLLayoutRenderer returns an HTMLLayoutRenderer and an error if something went wrong
+func NewHTMLLayoutRenderer(t, l io.Reader) (*HTMLLayoutRenderer, error) {
+	tmpl, err := newHTMLTemplate(t)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newHTMLTemplate(l)
+	if err != nil {
+		return nil, err
+	}
+	return &HTMLLayoutRenderer{tmpl, layout}, nil
+}
+
+// HTMLLayoutRenderer is an html/template renderer composing a template with a layout
+type HTMLLayoutRenderer struct {
+	tmpl   *template.Template
+	layout *template.Template
+}
+
+// Render implements the Renderer interface. The inner template is rendered
+// first and injected into the layout as a pre-escaped "Content" field so the
+// layout does not need to re-escape it.
+func (h HTMLLayoutRenderer) Render(w io.Writer, v interface{}) error {
+	var buf bytes.Buffer
+	if err := h.tmpl.Execute(&buf, v); err != nil {
+		return err
+	}
+	return h.layout.Execute(w, struct {
+		Content template.HTML
+		Data    interface{}
+	}{template.HTML(buf.String()), v})
+}
+
+func newHTMLTemplate(r io.Reader) (*template.Template, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("template").Parse(string(data))
+}
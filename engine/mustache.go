@@ -3,56 +3,88 @@ package engine
 import (
 	"io"
 	"io/ioutil"
-	"log"
-	"os"
 
 	"github.com/cbroglie/mustache"
 )
 
-// NewMustacheRendererMap returns a map with all renderers for the declared templates and layouts
-// and an error if something went wrong
-func NewMustacheRendererMap(cfg Config) (map[string]*MustacheRenderer, error) {
-	result := map[string]*MustacheRenderer{}
-	for _, section := range []map[string]string{cfg.Templates, cfg.Layouts} {
-		for name, path := range section {
-			templateFile, err := os.Open(path)
-			if err != nil {
-				log.Println("reading", path, ":", err.Error())
-				return result, err
-			}
-			renderer, err := NewMustacheRenderer(templateFile)
-			templateFile.Close()
-			if err != nil {
-				log.Println("parsing", path, ":", err.Error())
-				return result, err
-			}
-			result[name] = renderer
-		}
-	}
-	return result, nil
+func init() {
+	RegisterRendererFactory(EngineMustache, newMustacheRendererFactory)
+}
+
+func newMustacheRendererFactory(cfg Config) RendererFactory {
+	return mustacheRendererFactory{strict: cfg.StrictMode, helpers: mergeHelpers(cfg.Helpers)}
+}
+
+// mustacheRendererFactory is the RendererFactory for EngineMustache, backed
+// by github.com/cbroglie/mustache.
+type mustacheRendererFactory struct {
+	strict  bool
+	helpers FuncMap
+}
+
+func (f mustacheRendererFactory) New(t io.Reader) (Renderer, error) {
+	return newMustacheRenderer(t, f.strict, f.helpers)
+}
+
+func (f mustacheRendererFactory) NewWithLayout(t, l io.Reader) (Renderer, error) {
+	return newLayoutMustacheRenderer(t, l, f.strict, f.helpers)
 }
 
 // NewMustacheRenderer returns a MustacheRenderer and an error if something went wrong
 func NewMustacheRenderer(r io.Reader) (*MustacheRenderer, error) {
+	return newMustacheRenderer(r, false, nil)
+}
+
+// NewStrictMustacheRenderer is like NewMustacheRenderer, but the returned
+// Renderer's Render method returns a *MissingKeysError when the template
+// references a variable, section or partial that cannot be resolved.
+func NewStrictMustacheRenderer(r io.Reader) (*MustacheRenderer, error) {
+	return newMustacheRenderer(r, true, nil)
+}
+
+func newMustacheRenderer(r io.Reader, strict bool, helpers FuncMap) (*MustacheRenderer, error) {
 	tmpl, err := newMustacheTemplate(r)
 	if err != nil {
 		return nil, err
 	}
-	return &MustacheRenderer{tmpl}, nil
+	if helpers == nil {
+		helpers = mergeHelpers(nil)
+	}
+	return &MustacheRenderer{tmpl, strict, helpers}, nil
 }
 
 // MustacheRenderer is a simple mustache renderer with a single mustache template
 type MustacheRenderer struct {
-	tmpl *mustache.Template
+	tmpl    *mustache.Template
+	strict  bool
+	helpers FuncMap
 }
 
 // Render implements the renderer interface
 func (m MustacheRenderer) Render(w io.Writer, v interface{}) error {
-	return m.tmpl.FRender(w, v)
+	helpers := map[string]interface{}{"Helpers": map[string]interface{}(m.helpers)}
+	if m.strict {
+		if err := checkStrict([]interface{}{v, helpers}, m.tmpl); err != nil {
+			return err
+		}
+	}
+	return m.tmpl.FRender(w, v, helpers)
 }
 
 // NewLayoutMustacheRenderer returns a LayoutMustacheRenderer and an error if something went wrong
 func NewLayoutMustacheRenderer(t, l io.Reader) (*LayoutMustacheRenderer, error) {
+	return newLayoutMustacheRenderer(t, l, false, nil)
+}
+
+// NewStrictLayoutMustacheRenderer is like NewLayoutMustacheRenderer, but the
+// returned Renderer's Render method returns a *MissingKeysError when the
+// template or the layout reference a variable, section or partial that
+// cannot be resolved.
+func NewStrictLayoutMustacheRenderer(t, l io.Reader) (*LayoutMustacheRenderer, error) {
+	return newLayoutMustacheRenderer(t, l, true, nil)
+}
+
+func newLayoutMustacheRenderer(t, l io.Reader, strict bool, helpers FuncMap) (*LayoutMustacheRenderer, error) {
 	tmpl, err := newMustacheTemplate(t)
 	if err != nil {
 		return nil, err
@@ -61,18 +93,39 @@ func NewLayoutMustacheRenderer(t, l io.Reader) (*LayoutMustacheRenderer, error)
 	if err != nil {
 		return nil, err
 	}
-	return &LayoutMustacheRenderer{tmpl, layout}, nil
+	if helpers == nil {
+		helpers = mergeHelpers(nil)
+	}
+	return &LayoutMustacheRenderer{tmpl, layout, strict, helpers}, nil
 }
 
 // LayoutMustacheRenderer is a mustache renderer composing a mustache template with a layout
 type LayoutMustacheRenderer struct {
-	tmpl   *mustache.Template
-	layout *mustache.Template
+	tmpl    *mustache.Template
+	layout  *mustache.Template
+	strict  bool
+	helpers FuncMap
 }
 
 // Render implements the renderer interface
 func (m LayoutMustacheRenderer) Render(w io.Writer, v interface{}) error {
-	return m.tmpl.FRenderInLayout(w, m.layout, v)
+	helpers := map[string]interface{}{"Helpers": map[string]interface{}(m.helpers)}
+	if m.strict {
+		stack := []interface{}{v, helpers}
+		// FRenderInLayout renders the layout with one more frame in front,
+		// holding the rendered template under "content".
+		layoutStack := append([]interface{}{map[string]string{"content": ""}}, stack...)
+		var keys []string
+		for _, err := range []error{checkStrict(stack, m.tmpl), checkStrict(layoutStack, m.layout)} {
+			if missing, ok := err.(*MissingKeysError); ok {
+				keys = append(keys, missing.Keys...)
+			}
+		}
+		if len(keys) > 0 {
+			return &MissingKeysError{Keys: keys}
+		}
+	}
+	return m.tmpl.FRenderInLayout(w, m.layout, v, helpers)
 }
 
 func newMustacheTemplate(r io.Reader) (*mustache.Template, error) {
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"log"
+	"os"
+)
+
+// NewRendererMap returns a map with all renderers for the declared templates
+// and layouts and an error if something went wrong. Each entry is parsed by
+// the RendererFactory registered for its engine (see Config.Engines and
+// RegisterRendererFactory), so the result can freely mix mustache, html and
+// text templates.
+func NewRendererMap(cfg Config) (map[string]Renderer, error) {
+	result := map[string]Renderer{}
+	for _, section := range []map[string]string{cfg.Templates, cfg.Layouts} {
+		for name, path := range section {
+			factory, err := factoryFor(engineFor(name, path, cfg), cfg)
+			if err != nil {
+				log.Println("resolving engine for", path, ":", err.Error())
+				return result, err
+			}
+			templateFile, err := os.Open(path)
+			if err != nil {
+				log.Println("reading", path, ":", err.Error())
+				return result, err
+			}
+			renderer, err := factory.New(templateFile)
+			templateFile.Close()
+			if err != nil {
+				log.Println("parsing", path, ":", err.Error())
+				return result, err
+			}
+			result[name] = renderer
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewRendererMapDispatchesByExtension(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine-registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	htmlPath := filepath.Join(dir, "page.html")
+	if err := ioutil.WriteFile(htmlPath, []byte("<b>{{.Name}}</b>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	textPath := filepath.Join(dir, "page.tmpl")
+	if err := ioutil.WriteFile(textPath, []byte("hello {{.Name}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mustachePath := filepath.Join(dir, "page.mustache")
+	if err := ioutil.WriteFile(mustachePath, []byte("hi {{Name}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	renderers, err := NewRendererMap(Config{Templates: map[string]string{
+		"html":     htmlPath,
+		"text":     textPath,
+		"mustache": mustachePath,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{"html", map[string]string{"Name": "<script>"}, "<b>&lt;script&gt;</b>"},
+		{"text", map[string]string{"Name": "world"}, "hello world"},
+		{"mustache", map[string]string{"Name": "world"}, "hi world"},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		if err := renderers[c.name].Render(&buf, c.data); err != nil {
+			t.Fatalf("%s: Render() = %v", c.name, err)
+		}
+		if got := buf.String(); got != c.want {
+			t.Fatalf("%s: Render() output = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestEngineForExtensionFallback(t *testing.T) {
+	cfg := Config{}
+	if got := engineFor("x", "page.hbs", cfg); got != EngineMustache {
+		t.Fatalf("engineFor(.hbs) = %v, want %v (no dedicated handlebars engine yet)", got, EngineMustache)
+	}
+	if got := engineFor("x", "page.html", cfg); got != EngineHTML {
+		t.Fatalf("engineFor(.html) = %v, want %v", got, EngineHTML)
+	}
+	if got := engineFor("x", "page.tmpl", cfg); got != EngineText {
+		t.Fatalf("engineFor(.tmpl) = %v, want %v", got, EngineText)
+	}
+}
+
+func TestEngineForExplicitOverride(t *testing.T) {
+	cfg := Config{Engines: map[string]EngineName{"page": EngineText}}
+	if got := engineFor("page", "page.mustache", cfg); got != EngineText {
+		t.Fatalf("engineFor() = %v, want %v (explicit override)", got, EngineText)
+	}
+}
@@ -0,0 +1,9 @@
+package engine
+
+import "io"
+
+// Renderer renders a view into w. It is implemented by every template
+// engine supported by this package (mustache, html/template, text/template...).
+type Renderer interface {
+	Render(w io.Writer, v interface{}) error
+}
@@ -0,0 +1,133 @@
+package engine
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// RendererStore is a concurrency-safe, hot-reloadable view over the
+// renderers built by NewRendererMap. Get is safe to call from any goroutine
+// while Watch reloads templates in the background.
+type RendererStore struct {
+	cfg       Config
+	paths     map[string]string // name -> file path, for the watcher
+	renderers sync.Map          // name -> Renderer
+	onReload  func(name string, err error)
+}
+
+// NewRendererStore builds the initial set of renderers from cfg and returns a
+// RendererStore ready to serve them. onReload, if non-nil, is called after
+// every reload attempt triggered by Watch, with a nil err on success.
+func NewRendererStore(cfg Config, onReload func(name string, err error)) (*RendererStore, error) {
+	renderers, err := NewRendererMap(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &RendererStore{cfg: cfg, paths: map[string]string{}, onReload: onReload}
+	for _, section := range []map[string]string{cfg.Templates, cfg.Layouts} {
+		for name, path := range section {
+			s.paths[name] = path
+		}
+	}
+	for name, r := range renderers {
+		s.renderers.Store(name, r)
+	}
+	return s, nil
+}
+
+// Get returns the Renderer currently registered under name, or nil if there is none.
+func (s *RendererStore) Get(name string) Renderer {
+	v, ok := s.renderers.Load(name)
+	if !ok {
+		return nil
+	}
+	return v.(Renderer)
+}
+
+// Watch starts an fsnotify watcher on the directories containing every
+// template/layout file and re-parses + atomically swaps in whichever
+// renderer changed. A failed reload keeps the previously served renderer and
+// is reported through onReload instead of stopping the watcher. Watch blocks
+// until stop is closed.
+//
+// The directories, rather than the files themselves, are watched because
+// most editors (and atomic deploy tooling) save by writing a temp file and
+// renaming it over the target: that only emits an event for the directory,
+// and it would leave a file-level watch pointed at a deleted inode.
+func (s *RendererStore) Watch(stop <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	watched := map[string]bool{}
+	for _, path := range s.paths {
+		dir := filepath.Dir(path)
+		if watched[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+		watched[dir] = true
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			s.reload(event.Name)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Println("watching templates:", err.Error())
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// reload re-parses every name backed by eventPath and atomically swaps in the
+// new renderer, reporting the outcome through s.onReload. The previously
+// served renderer for name is left untouched if parsing fails.
+func (s *RendererStore) reload(eventPath string) {
+	eventPath = filepath.Clean(eventPath)
+	for name, path := range s.paths {
+		if filepath.Clean(path) != eventPath {
+			continue
+		}
+		renderer, err := s.parse(name, path)
+		if err == nil {
+			s.renderers.Store(name, renderer)
+		} else {
+			log.Println("reloading", path, ":", err.Error())
+		}
+		if s.onReload != nil {
+			s.onReload(name, err)
+		}
+	}
+}
+
+func (s *RendererStore) parse(name, path string) (Renderer, error) {
+	factory, err := factoryFor(engineFor(name, path, s.cfg), s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return factory.New(f)
+}
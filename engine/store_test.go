@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRendererStoreWatchAtomicRename(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "page.mustache")
+	if err := ioutil.WriteFile(path, []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloads := make(chan error, 4)
+	store, err := NewRendererStore(Config{Templates: map[string]string{"page": path}}, func(name string, err error) {
+		reloads <- err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go store.Watch(stop)
+
+	// Most editors (and atomic deploy tooling) save by writing a temp file
+	// and renaming it over the target, which only emits a directory-level
+	// event, not a write on the original file.
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-reloads:
+		if err != nil {
+			t.Fatalf("reload reported error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("atomic rename did not trigger a reload")
+	}
+
+	var buf bytes.Buffer
+	if err := store.Get("page").Render(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "v2" {
+		t.Fatalf("Render() output = %q, want %q", got, "v2")
+	}
+}
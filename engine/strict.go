@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cbroglie/mustache"
+)
+
+// MissingKeysError is returned by MustacheRenderer.Render and
+// LayoutMustacheRenderer.Render when the renderer was built with strict mode
+// enabled and the template references a variable, section or partial that
+// cannot be resolved against the given data.
+type MissingKeysError struct {
+	Keys []string
+}
+
+func (e *MissingKeysError) Error() string {
+	return fmt.Sprintf("engine: missing keys: %s", strings.Join(e.Keys, ", "))
+}
+
+// checkStrict walks every tag of every template in tmpls, recursing into
+// section bodies against the context each iteration would push, and returns
+// a *MissingKeysError listing every variable, section or partial that cannot
+// be resolved against stack. stack must mirror, top-of-stack (most recently
+// pushed) first, the exact context frames passed to FRender/FRenderInLayout
+// -- e.g. the Helpers frame Render adds alongside v -- or it will flag
+// perfectly valid lookups as missing.
+func checkStrict(stack []interface{}, tmpls ...*mustache.Template) error {
+	var missing []string
+	seen := map[string]bool{}
+	record := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			missing = append(missing, name)
+		}
+	}
+	for _, tmpl := range tmpls {
+		walkTags(tmpl.Tags(), stack, record)
+	}
+	if len(missing) > 0 {
+		return &MissingKeysError{Keys: missing}
+	}
+	return nil
+}
+
+// walkTags checks tags against stack, the mustache context stack in effect at
+// that point (innermost context first), recursing into Section/InvertedSection
+// bodies against the stack each one pushes for its iterations.
+func walkTags(tags []mustache.Tag, stack []interface{}, record func(string)) {
+	for _, tag := range tags {
+		switch tag.Type() {
+		case mustache.Variable:
+			if !resolves(stack, tag.Name()) {
+				record(tag.Name())
+			}
+		case mustache.Partial:
+			// The mustache partial providers return an empty template and a
+			// nil error for a partial they cannot find.
+			if data, err := customPartialProvider.Get(tag.Name()); err != nil || data == "" {
+				record(tag.Name())
+			}
+		case mustache.Section, mustache.InvertedSection:
+			val, ok := lookup(stack, tag.Name())
+			if !ok {
+				record(tag.Name())
+				continue
+			}
+			for _, next := range sectionStacks(stack, val, tag.Type() == mustache.InvertedSection) {
+				walkTags(tag.Tags(), next, record)
+			}
+		}
+	}
+}
+
+// sectionStacks returns the context stack to check the section body against
+// for each time it would be rendered, or nil if the section (given val and
+// whether it is inverted) would not render at all.
+func sectionStacks(stack []interface{}, val interface{}, inverted bool) [][]interface{} {
+	truthy := isTruthy(val)
+	if inverted {
+		if truthy {
+			return nil
+		}
+		return [][]interface{}{stack}
+	}
+	if !truthy {
+		return nil
+	}
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		stacks := make([][]interface{}, rv.Len())
+		for i := range stacks {
+			stacks[i] = append([]interface{}{rv.Index(i).Interface()}, stack...)
+		}
+		return stacks
+	}
+	return [][]interface{}{append([]interface{}{val}, stack...)}
+}
+
+// isTruthy mirrors mustache's rule for whether a section renders: false,
+// nil and empty lists/maps don't, everything else does.
+func isTruthy(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return false
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	default:
+		return true
+	}
+}
+
+// resolves reports whether the dotted path name can be looked up somewhere in stack.
+func resolves(stack []interface{}, name string) bool {
+	_, ok := lookup(stack, name)
+	return ok
+}
+
+// lookup resolves the dotted path name against stack the way the mustache
+// context stack would: the first path component is searched from the
+// innermost context outward, and the remaining components are then resolved
+// on whatever it found, without falling back further.
+func lookup(stack []interface{}, name string) (interface{}, bool) {
+	if name == "." || name == "" {
+		if len(stack) == 0 {
+			return nil, false
+		}
+		return stack[0], true
+	}
+	parts := strings.Split(name, ".")
+	for _, ctx := range stack {
+		head, ok := lookupPath(ctx, parts[:1])
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 {
+			return head, true
+		}
+		return lookupPath(head, parts[1:])
+	}
+	return nil, false
+}
+
+func lookupPath(v interface{}, parts []string) (interface{}, bool) {
+	cur := reflect.ValueOf(v)
+	for _, part := range parts {
+		for cur.Kind() == reflect.Ptr || cur.Kind() == reflect.Interface {
+			if cur.IsNil() {
+				return nil, false
+			}
+			cur = cur.Elem()
+		}
+		switch cur.Kind() {
+		case reflect.Map:
+			val := cur.MapIndex(reflect.ValueOf(part))
+			if !val.IsValid() {
+				return nil, false
+			}
+			cur = val
+		case reflect.Struct:
+			field := cur.FieldByName(part)
+			if !field.IsValid() {
+				return nil, false
+			}
+			cur = field
+		default:
+			return nil, false
+		}
+	}
+	if !cur.IsValid() {
+		return nil, false
+	}
+	return cur.Interface(), true
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestStrictModeMissingNestedVariable(t *testing.T) {
+	r, err := NewStrictMustacheRenderer(strings.NewReader("{{#Items}}{{Name}}{{/Items}}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]interface{}{
+		"Items": []map[string]interface{}{{"NotName": "x"}},
+	}
+	err = r.Render(ioutil.Discard, data)
+
+	var missing *MissingKeysError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Render error = %v, want *MissingKeysError", err)
+	}
+	if len(missing.Keys) != 1 || missing.Keys[0] != "Name" {
+		t.Fatalf("missing keys = %v, want [Name]", missing.Keys)
+	}
+}
+
+func TestStrictModeWithHelpers(t *testing.T) {
+	r, err := NewStrictMustacheRenderer(strings.NewReader("{{#Helpers.uppercase}}{{Name}}{{/Helpers.uppercase}}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, map[string]interface{}{"Name": "ada"}); err != nil {
+		t.Fatalf("Render() = %v, want nil", err)
+	}
+	if got := buf.String(); got != "ADA" {
+		t.Fatalf("Render() output = %q, want %q", got, "ADA")
+	}
+}
+
+func TestStrictModeResolvedNestedVariable(t *testing.T) {
+	r, err := NewStrictMustacheRenderer(strings.NewReader("{{#Items}}{{Name}}{{/Items}}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]interface{}{
+		"Items": []map[string]interface{}{{"Name": "x"}},
+	}
+	if err := r.Render(ioutil.Discard, data); err != nil {
+		t.Fatalf("Render() = %v, want nil", err)
+	}
+}
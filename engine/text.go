@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"text/template"
+)
+
+func init() {
+	RegisterRendererFactory(EngineText, func(Config) RendererFactory {
+		return textRendererFactory{}
+	})
+}
+
+// textRendererFactory is the RendererFactory for EngineText, backed by the
+// standard library's text/template. Useful for non-HTML outputs (JSON, CSV,
+// plain text) where mustache's logic-less rules get in the way.
+type textRendererFactory struct{}
+
+func (textRendererFactory) New(t io.Reader) (Renderer, error) {
+	return NewTextRenderer(t)
+}
+
+func (textRendererFactory) NewWithLayout(t, l io.Reader) (Renderer, error) {
+	return NewTextLayoutRenderer(t, l)
+}
+
+// TextRenderer is a simple text/template renderer with a single template
+type TextRenderer struct {
+	tmpl *template.Template
+}
+
+// NewTextRenderer returns a TextRenderer and an error if something went wrong
+func NewTextRenderer(r io.Reader) (*TextRenderer, error) {
+	tmpl, err := newTextTemplate(r)
+	if err != nil {
+		return nil, err
+	}
+	return &TextRenderer{tmpl}, nil
+}
+
+// Render implements the Renderer interface
+func (t TextRenderer) Render(w io.Writer, v interface{}) error {
+	return t.tmpl.Execute(w, v)
+}
+
+// NewTextLayoutRenderer returns a TextLayoutRenderer and an error if something went wrong
+func NewTextLayoutRenderer(t, l io.Reader) (*TextLayoutRenderer, error) {
+	tmpl, err := newTextTemplate(t)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newTextTemplate(l)
+	if err != nil {
+		return nil, err
+	}
+	return &TextLayoutRenderer{tmpl, layout}, nil
+}
+
+// TextLayoutRenderer is a text/template renderer composing a template with a layout
+type TextLayoutRenderer struct {
+	tmpl   *template.Template
+	layout *template.Template
+}
+
+// Render implements the Renderer interface
+func (t TextLayoutRenderer) Render(w io.Writer, v interface{}) error {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, v); err != nil {
+		return err
+	}
+	return t.layout.Execute(w, struct {
+		Content string
+		Data    interface{}
+	}{buf.String(), v})
+}
+
+func newTextTemplate(r io.Reader) (*template.Template, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("template").Parse(string(data))
+}